@@ -0,0 +1,91 @@
+//
+// Copyright (c) 2019 Stefaan Coussement
+// MIT License
+//
+// more info: https://github.com/stefaanc/golang-exec
+//
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+//------------------------------------------------------------------------------
+
+// defaultKillGrace is the time a Runner waits after sending SIGTERM, on context
+// cancellation or deadline expiry, before escalating to SIGKILL and closing the
+// session, when Runner.KillGrace is left at the zero value.
+var defaultKillGrace = 5 * time.Second
+
+//------------------------------------------------------------------------------
+
+// RunContext is like Run, but sends SIGTERM and, after r.KillGrace, SIGKILL to the
+// remote process when ctx is cancelled or its deadline expires.
+func (r *Runner) RunContext(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Run()
+	}()
+
+	return r.waitContext(ctx, done)
+}
+
+// StartContext starts the command exactly like Start.  ctx is not consulted: its
+// cancellation has no effect on the started command, which is only signalled through
+// the ctx passed to a subsequent call to WaitContext.
+func (r *Runner) StartContext(ctx context.Context) error {
+	return r.Start()
+}
+
+// WaitContext is like Wait, but sends SIGTERM and, after r.KillGrace, SIGKILL to the
+// remote process when ctx is cancelled or its deadline expires before the command
+// started by StartContext completes.
+func (r *Runner) WaitContext(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Wait()
+	}()
+
+	return r.waitContext(ctx, done)
+}
+
+// waitContext blocks until done completes or ctx is cancelled, in which case it
+// escalates SIGTERM -> SIGKILL against the remote session.
+func (r *Runner) waitContext(ctx context.Context, done chan error) error {
+	select {
+	case err := <-done:
+		return err
+
+	case <-ctx.Done():
+		grace := r.KillGrace
+		if grace == 0 {
+			grace = defaultKillGrace
+		}
+
+		_ = r.session.Signal(ssh.SIGTERM)
+
+		select {
+		case err := <-done:
+			return err
+
+		case <-time.After(grace):
+			_ = r.session.Signal(ssh.SIGKILL)
+			_ = r.session.Close()
+			r.setRunning(false)
+			r.setExitCode(-1)
+
+			return &Error{
+				script:   r.script,
+				command:  r.command,
+				exitCode: r.ExitCode(),
+				err:      fmt.Errorf("[golang-exec/runner/ssh/waitContext()] runner cancelled: %w", ctx.Err()),
+			}
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
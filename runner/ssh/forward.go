@@ -0,0 +1,119 @@
+//
+// Copyright (c) 2019 Stefaan Coussement
+// MIT License
+//
+// more info: https://github.com/stefaanc/golang-exec
+//
+package ssh
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+//------------------------------------------------------------------------------
+
+// Dial opens a connection to addr through the Runner's SSH connection, letting callers
+// build a custom net.Dialer / http.Transport that tunnels through SSH to reach e.g. a
+// Docker socket or an internal HTTP API on the target.
+func (r *Runner) Dial(network, addr string) (net.Conn, error) {
+	conn, err := r.client.Dial(network, addr)
+	if err != nil {
+		return nil, &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/Dial()] cannot dial %s: %#w\n", addr, err),
+		}
+	}
+
+	return conn, nil
+}
+
+// ForwardLocal listens on localAddr and, for every accepted connection, dials
+// remoteAddr through the Runner's SSH connection and pipes the two together, emulating
+// `ssh -L localAddr:remoteAddr`.  Closing the returned io.Closer stops the listener.
+func (r *Runner) ForwardLocal(localAddr, remoteAddr string) (io.Closer, error) {
+	listener, err := net.Listen("tcp", localAddr)
+	if err != nil {
+		return nil, &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/ForwardLocal()] cannot listen on %s: %#w\n", localAddr, err),
+		}
+	}
+
+	go func() {
+		for {
+			local, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			remote, err := r.client.Dial("tcp", remoteAddr)
+			if err != nil {
+				r.log().Warn("forward-local-dial-failed", "remoteAddr", remoteAddr, "error", err)
+				local.Close()
+				continue
+			}
+
+			go pipe(local, remote)
+		}
+	}()
+
+	return listener, nil
+}
+
+// ForwardRemote asks the remote SSH server to listen on remoteAddr and, for every
+// connection it accepts, dials localAddr on this side and pipes the two together,
+// emulating `ssh -R remoteAddr:localAddr`.  Closing the returned io.Closer stops the
+// remote listener.
+func (r *Runner) ForwardRemote(remoteAddr, localAddr string) (io.Closer, error) {
+	listener, err := r.client.Listen("tcp", remoteAddr)
+	if err != nil {
+		return nil, &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/ForwardRemote()] cannot listen on remote %s: %#w\n", remoteAddr, err),
+		}
+	}
+
+	go func() {
+		for {
+			remote, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			local, err := net.Dial("tcp", localAddr)
+			if err != nil {
+				r.log().Warn("forward-remote-dial-failed", "localAddr", localAddr, "error", err)
+				remote.Close()
+				continue
+			}
+
+			go pipe(local, remote)
+		}
+	}()
+
+	return listener, nil
+}
+
+// pipe copies data in both directions between a and b until either side is closed.
+func pipe(a, b net.Conn) {
+	defer a.Close()
+	defer b.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(a, b)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(b, a)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+//------------------------------------------------------------------------------
@@ -0,0 +1,47 @@
+//
+// Copyright (c) 2019 Stefaan Coussement
+// MIT License
+//
+// more info: https://github.com/stefaanc/golang-exec
+//
+package ssh
+
+//------------------------------------------------------------------------------
+
+// Logger is implemented by loggers that can be plugged into a Runner via SetLogger.
+// Each method takes a message and an even number of key-value pairs, following the
+// convention of structured loggers such as zerolog or logr.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// nopLogger is the default Logger used by a Runner until SetLogger is called.
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, keyvals ...interface{}) {}
+func (nopLogger) Info(msg string, keyvals ...interface{})  {}
+func (nopLogger) Warn(msg string, keyvals ...interface{})  {}
+func (nopLogger) Error(msg string, keyvals ...interface{}) {}
+
+//------------------------------------------------------------------------------
+
+// SetLogger plugs a structured Logger into the Runner.  Once set, the Runner emits
+// events for pty-request, run-start and run-exit (from Run) and close instead of
+// writing to stdout.  The dial and session-open events are logged inside New, before
+// a logger can be set, so they never reach a Logger set here.
+func (r *Runner) SetLogger(logger Logger) {
+	r.logger = logger
+}
+
+// log returns the Runner's logger, or a no-op logger when none has been set.
+func (r *Runner) log() Logger {
+	if r.logger == nil {
+		return nopLogger{}
+	}
+	return r.logger
+}
+
+//------------------------------------------------------------------------------
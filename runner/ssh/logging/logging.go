@@ -0,0 +1,72 @@
+//
+// Copyright (c) 2019 Stefaan Coussement
+// MIT License
+//
+// more info: https://github.com/stefaanc/golang-exec
+//
+// Package logging wires a zerolog.Logger writing to a rotated lumberjack.Logger into
+// the runner/ssh.Logger interface, so a service embedding this module gets bounded
+// log files without building a bespoke logging pipeline.
+package logging
+
+import (
+	"github.com/natefinch/lumberjack"
+	"github.com/rs/zerolog"
+)
+
+//------------------------------------------------------------------------------
+
+// Options configures the rotated log file backing a Logger.
+type Options struct {
+	// Filename is the log file to write to.
+	Filename string
+	// MaxSizeMB is the maximum size in megabytes of a log file before it gets rotated.
+	MaxSizeMB int
+	// MaxAgeDays is the maximum number of days to retain old log files.
+	MaxAgeDays int
+	// MaxBackups is the maximum number of old log files to retain.
+	MaxBackups int
+	// Compress determines whether rotated log files are compressed with gzip.
+	Compress bool
+}
+
+//------------------------------------------------------------------------------
+
+// Logger adapts a zerolog.Logger, backed by a rotating lumberjack.Logger, to the
+// runner/ssh.Logger interface.
+type Logger struct {
+	zl zerolog.Logger
+}
+
+// New creates a Logger that writes structured, size/age/backup-rotated log entries
+// to opts.Filename.
+func New(opts Options) *Logger {
+	writer := &lumberjack.Logger{
+		Filename:   opts.Filename,
+		MaxSize:    opts.MaxSizeMB,
+		MaxAge:     opts.MaxAgeDays,
+		MaxBackups: opts.MaxBackups,
+		Compress:   opts.Compress,
+	}
+
+	return &Logger{zl: zerolog.New(writer).With().Timestamp().Logger()}
+}
+
+func (l *Logger) Debug(msg string, keyvals ...interface{}) { l.event(l.zl.Debug(), msg, keyvals) }
+func (l *Logger) Info(msg string, keyvals ...interface{})  { l.event(l.zl.Info(), msg, keyvals) }
+func (l *Logger) Warn(msg string, keyvals ...interface{})  { l.event(l.zl.Warn(), msg, keyvals) }
+func (l *Logger) Error(msg string, keyvals ...interface{}) { l.event(l.zl.Error(), msg, keyvals) }
+
+// event attaches an even number of key-value pairs to a zerolog.Event and sends msg.
+func (l *Logger) event(e *zerolog.Event, msg string, keyvals []interface{}) {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			continue
+		}
+		e = e.Interface(key, keyvals[i+1])
+	}
+	e.Msg(msg)
+}
+
+//------------------------------------------------------------------------------
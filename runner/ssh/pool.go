@@ -0,0 +1,189 @@
+//
+// Copyright (c) 2019 Stefaan Coussement
+// MIT License
+//
+// more info: https://github.com/stefaanc/golang-exec
+//
+package ssh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+//------------------------------------------------------------------------------
+
+// poolKey identifies a pooled *ssh.Client by host, port, user and the fingerprint of
+// its auth configuration, so that Connections differing only in e.g. Insecure don't
+// collide.
+type poolKey struct {
+	host   string
+	port   uint16
+	user   string
+	authFP string
+}
+
+// pooledClient is a refcounted *ssh.Client shared by every Runner dialing the same
+// poolKey, with a background keepalive loop that re-dials on failure.  ready is closed
+// once the initial dial completes (successfully or not), so callers racing on the same
+// not-yet-dialed key can wait on it without holding Pool.mu across the dial.
+type pooledClient struct {
+	client        *ssh.Client
+	config        *ssh.ClientConfig
+	address       string
+	refCount      int
+	stopKeepalive chan struct{}
+	ready         chan struct{}
+	dialErr       error
+}
+
+// Pool dials a single *ssh.Client per distinct (host, port, user, auth) and hands out
+// a fresh *ssh.Session per invocation, so fan-out orchestrations against many hosts
+// reuse one TCP+SSH handshake per host instead of paying for it on every New().
+type Pool struct {
+	mu                sync.Mutex
+	clients           map[poolKey]*pooledClient
+	KeepaliveInterval time.Duration
+}
+
+//------------------------------------------------------------------------------
+
+// NewPool creates an empty Pool.  KeepaliveInterval defaults to 30 seconds when left
+// at the zero value.
+func NewPool() *Pool {
+	return &Pool{
+		clients: make(map[poolKey]*pooledClient),
+	}
+}
+
+// dial returns the shared *ssh.Client for key, dialing and starting a keepalive loop
+// the first time key is seen.  The blocking ssh.Dial runs outside of p.mu, so New()
+// calls to distinct keys dial concurrently instead of serializing on the pool lock;
+// callers racing on the same not-yet-dialed key wait on pc.ready instead.
+func (p *Pool) dial(key poolKey, address string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	p.mu.Lock()
+	if pc, ok := p.clients[key]; ok {
+		pc.refCount++
+		p.mu.Unlock()
+
+		<-pc.ready
+		if pc.dialErr != nil {
+			return nil, pc.dialErr
+		}
+		return pc.client, nil
+	}
+
+	pc := &pooledClient{
+		config:        config,
+		address:       address,
+		refCount:      1,
+		stopKeepalive: make(chan struct{}),
+		ready:         make(chan struct{}),
+	}
+	p.clients[key] = pc
+	p.mu.Unlock()
+
+	client, err := ssh.Dial("tcp", address, config)
+	if err != nil {
+		p.mu.Lock()
+		delete(p.clients, key)
+		p.mu.Unlock()
+
+		pc.dialErr = fmt.Errorf("[golang-exec/runner/ssh/Pool.dial()] cannot dial host: %w", err)
+		close(pc.ready)
+		return nil, pc.dialErr
+	}
+
+	p.mu.Lock()
+	pc.client = client
+	p.mu.Unlock()
+	close(pc.ready)
+	go p.keepalive(key, pc)
+
+	return client, nil
+}
+
+// keepalive periodically probes a pooled client and re-dials it when the probe fails.
+func (p *Pool) keepalive(key poolKey, pc *pooledClient) {
+	interval := p.KeepaliveInterval
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pc.stopKeepalive:
+			return
+
+		case <-ticker.C:
+			_, _, err := pc.client.SendRequest("keepalive@openssh.com", true, nil)
+			if err != nil {
+				client, dialErr := ssh.Dial("tcp", pc.address, pc.config)
+				if dialErr != nil {
+					continue
+				}
+
+				p.mu.Lock()
+				pc.client.Close()
+				pc.client = client
+				p.mu.Unlock()
+			}
+		}
+	}
+}
+
+// release drops a reference to the pooled client for key, closing and forgetting it
+// once no Runner references it anymore.
+func (p *Pool) release(key poolKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pc, ok := p.clients[key]
+	if !ok {
+		return
+	}
+
+	pc.refCount--
+	if pc.refCount <= 0 {
+		close(pc.stopKeepalive)
+		pc.client.Close()
+		delete(p.clients, key)
+	}
+}
+
+// Close drains and closes every pooled *ssh.Client, regardless of outstanding
+// references.  Runners still holding a session on a closed client will fail on
+// their next operation.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, pc := range p.clients {
+		close(pc.stopKeepalive)
+		pc.client.Close()
+		delete(p.clients, key)
+	}
+
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+// authFingerprint derives a stable, non-secret key for a Connection's auth
+// configuration and host-key policy, so that otherwise-identical connections using
+// different credentials, or a different Insecure setting, don't share a pooled client.
+func authFingerprint(c *Connection) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s|%t|%t|%t",
+		c.PrivateKey, c.PrivateKeyPath, c.Certificate, c.Password, c.UseAgent, c.KeyboardInteractive != nil, c.Insecure)))
+	return hex.EncodeToString(sum[:])
+}
+
+//------------------------------------------------------------------------------
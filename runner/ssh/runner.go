@@ -11,13 +11,17 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/crypto/ssh/knownhosts"
 	"golang.org/x/crypto/ssh/terminal"
 
@@ -33,6 +37,24 @@ type Connection struct {
 	User     string
 	Password string
 	Insecure bool
+
+	// PrivateKey holds a PEM-encoded private key, tried before Password when set.
+	PrivateKey string
+	// PrivateKeyPath points to a PEM-encoded private key file, used when PrivateKey is empty.
+	PrivateKeyPath string
+	// Passphrase decrypts PrivateKey or PrivateKeyPath when the key is encrypted.
+	Passphrase string
+	// Certificate holds an OpenSSH/Teleport CA-signed public key, used together with
+	// PrivateKey or PrivateKeyPath to authenticate with a short-lived user certificate.
+	Certificate string
+	// UseAgent dials $SSH_AUTH_SOCK and offers the agent's keys for authentication.
+	UseAgent bool
+	// KeyboardInteractive answers keyboard-interactive authentication challenges.
+	KeyboardInteractive func(name, instruction string, questions []string, echos []bool) ([]string, error)
+
+	// Jump lists bastion hops to dial, in order, before reaching Host.  Each hop is
+	// dialed from the previous one, mirroring OpenSSH's ProxyJump.
+	Jump []Connection
 }
 
 type Error struct {
@@ -47,9 +69,24 @@ type Runner struct {
 	command string
 	client  *ssh.Client
 	session *ssh.Session
-	running bool
+	logger  Logger
+
+	pool    *Pool
+	poolKey poolKey
+
+	// jumpClients holds the intermediate *ssh.Client of each bastion hop, in dial
+	// order, so Close() can tear them down in reverse.
+	jumpClients []*ssh.Client
 
+	// mu guards running and exitCode, which Run/Wait and the RunContext/WaitContext
+	// escalation path can write from different goroutines.
+	mu       sync.Mutex
+	running  bool
 	exitCode int
+
+	// KillGrace is the time RunContext/WaitContext wait after SIGTERM before
+	// escalating to SIGKILL.  Defaults to 5 seconds when zero.
+	KillGrace time.Duration
 }
 
 //------------------------------------------------------------------------------
@@ -63,6 +100,18 @@ func (e *Error) Unwrap() error          { return e.err }
 //------------------------------------------------------------------------------
 
 func New(connection interface{}, s *script.Script, arguments interface{}) (*Runner, error) {
+	return newRunner(nil, connection, s, arguments)
+}
+
+// NewWithPool is like New, but dials through pool, reusing an existing *ssh.Client for
+// the same (host, port, user, auth) instead of dialing and authenticating again.  This
+// is the preferred constructor for fan-out orchestrations hitting many hosts.  A
+// connection with Jump hops is dialed through its jump chain and does not use pool.
+func NewWithPool(pool *Pool, connection interface{}, s *script.Script, arguments interface{}) (*Runner, error) {
+	return newRunner(pool, connection, s, arguments)
+}
+
+func newRunner(pool *Pool, connection interface{}, s *script.Script, arguments interface{}) (*Runner, error) {
 	if s.Error != nil {
 		return nil, &Error{
 			script:   s,
@@ -91,47 +140,61 @@ func New(connection interface{}, s *script.Script, arguments interface{}) (*Runn
 
 	address := fmt.Sprintf("%s:%d", c.Host, c.Port)
 
-	config := &ssh.ClientConfig{
-		User: c.User,
-		Auth: []ssh.AuthMethod{
-			ssh.Password(c.Password),
-		},
+	config, err := clientConfig(c)
+	if err != nil {
+		return nil, &Error{
+			script:   s,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/New()] cannot build client config: %#w\n", err),
+		}
 	}
-	if c.Insecure {
-		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
-	} else {
-		f, err := homedir.Expand("~/.ssh/known_hosts")
+
+	var client *ssh.Client
+	switch {
+	case len(c.Jump) > 0:
+		client, r.jumpClients, err = dialJumpChain(c)
 		if err != nil {
 			return nil, &Error{
 				script:   s,
 				exitCode: -1,
-				err:      fmt.Errorf("[golang-exec/runner/ssh/New()] cannot find home directory of current user: %#w\n", err),
+				err:      fmt.Errorf("[golang-exec/runner/ssh/New()] cannot dial through jump chain: %#w\n", err),
 			}
 		}
 
-		hostKeyCallback, err := knownhosts.New(f)
+	case pool != nil:
+		key := poolKey{host: c.Host, port: c.Port, user: c.User, authFP: authFingerprint(c)}
+		client, err = pool.dial(key, address, config)
 		if err != nil {
 			return nil, &Error{
 				script:   s,
 				exitCode: -1,
-				err:      fmt.Errorf("[golang-exec/runner/ssh/New()] cannot access 'known_hosts'-file: %#w\n", err),
+				err:      fmt.Errorf("[golang-exec/runner/ssh/New()] cannot dial host: %#w\n", err),
 			}
 		}
-		config.HostKeyCallback = hostKeyCallback
-	}
+		r.pool = pool
+		r.poolKey = key
 
-	client, err := ssh.Dial("tcp", address, config)
-	if err != nil {
-		return nil, &Error{
-			script:   s,
-			exitCode: -1,
-			err:      fmt.Errorf("[golang-exec/runner/ssh/New()] cannot dial host: %#w\n", err),
+	default:
+		client, err = ssh.Dial("tcp", address, config)
+		if err != nil {
+			return nil, &Error{
+				script:   s,
+				exitCode: -1,
+				err:      fmt.Errorf("[golang-exec/runner/ssh/New()] cannot dial host: %#w\n", err),
+			}
 		}
 	}
 	r.client = client
+	r.log().Info("dial", "host", c.Host, "port", c.Port)
 
 	session, err := client.NewSession()
 	if err != nil {
+		if r.pool != nil {
+			r.pool.release(r.poolKey)
+		} else {
+			client.Close()
+			closeClients(r.jumpClients)
+		}
 		return nil, &Error{
 			script:   s,
 			exitCode: -1,
@@ -139,6 +202,7 @@ func New(connection interface{}, s *script.Script, arguments interface{}) (*Runn
 		}
 	}
 	r.session = session
+	r.log().Info("session-open")
 	// r.session.Stdin = os.Stdin
 
 	return r, nil
@@ -155,6 +219,19 @@ func toConnection(connection interface{}) *Connection {
 		c.User = v.FieldByName("User").String()
 		c.Password = v.FieldByName("Password").String()
 		c.Insecure = v.FieldByName("Insecure").Bool()
+		c.PrivateKey = v.FieldByName("PrivateKey").String()
+		c.PrivateKeyPath = v.FieldByName("PrivateKeyPath").String()
+		c.Passphrase = v.FieldByName("Passphrase").String()
+		c.Certificate = v.FieldByName("Certificate").String()
+		c.UseAgent = v.FieldByName("UseAgent").Bool()
+		if f := v.FieldByName("KeyboardInteractive"); f.IsValid() && !f.IsNil() {
+			c.KeyboardInteractive = f.Interface().(func(name, instruction string, questions []string, echos []bool) ([]string, error))
+		}
+		if jump := v.FieldByName("Jump"); jump.IsValid() && jump.Kind() == reflect.Slice {
+			for i := 0; i < jump.Len(); i++ {
+				c.Jump = append(c.Jump, *toConnection(jump.Index(i).Interface()))
+			}
+		}
 	} else if v.Kind() == reflect.Map {
 		iter := v.MapRange()
 		for iter.Next() {
@@ -179,6 +256,30 @@ func toConnection(connection interface{}) *Connection {
 					b = false
 				}
 				c.Insecure = b
+			case "PrivateKey":
+				c.PrivateKey = iter.Value().String()
+			case "PrivateKeyPath":
+				c.PrivateKeyPath = iter.Value().String()
+			case "Passphrase":
+				c.Passphrase = iter.Value().String()
+			case "Certificate":
+				c.Certificate = iter.Value().String()
+			case "UseAgent":
+				b, err := strconv.ParseBool(strings.ToLower(iter.Value().String()))
+				if err != nil {
+					b = false
+				}
+				c.UseAgent = b
+			case "Jump":
+				jump := reflect.Indirect(iter.Value())
+				if jump.Kind() == reflect.Interface {
+					jump = jump.Elem()
+				}
+				if jump.Kind() == reflect.Slice {
+					for i := 0; i < jump.Len(); i++ {
+						c.Jump = append(c.Jump, *toConnection(jump.Index(i).Interface()))
+					}
+				}
 			}
 		}
 	}
@@ -186,6 +287,179 @@ func toConnection(connection interface{}) *Connection {
 	return c
 }
 
+// clientConfig builds an *ssh.ClientConfig for c, with an ordered Auth method list and
+// a HostKeyCallback based on c.Insecure / the current user's known_hosts file.
+func clientConfig(c *Connection) (*ssh.ClientConfig, error) {
+	auth, err := authMethods(c)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build auth methods: %w", err)
+	}
+
+	config := &ssh.ClientConfig{
+		User: c.User,
+		Auth: auth,
+	}
+	if c.Insecure {
+		config.HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	} else {
+		f, err := homedir.Expand("~/.ssh/known_hosts")
+		if err != nil {
+			return nil, fmt.Errorf("cannot find home directory of current user: %w", err)
+		}
+
+		hostKeyCallback, err := knownhosts.New(f)
+		if err != nil {
+			return nil, fmt.Errorf("cannot access 'known_hosts'-file: %w", err)
+		}
+		config.HostKeyCallback = hostKeyCallback
+	}
+
+	return config, nil
+}
+
+// dialJumpChain dials c.Jump in order, each hop tunneled through the previous one,
+// then dials c itself through the last hop.  It returns the final *ssh.Client and the
+// intermediate clients in dial order, so the caller can close them in reverse.
+func dialJumpChain(c *Connection) (*ssh.Client, []*ssh.Client, error) {
+	hops := append(append([]Connection{}, c.Jump...), *c)
+
+	var clients []*ssh.Client
+	for i, hop := range hops {
+		hop := hop
+		config, err := clientConfig(&hop)
+		if err != nil {
+			closeClients(clients)
+			return nil, nil, fmt.Errorf("cannot build client config for hop %d: %w", i, err)
+		}
+
+		address := fmt.Sprintf("%s:%d", hop.Host, hop.Port)
+
+		if i == 0 {
+			client, err := ssh.Dial("tcp", address, config)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot dial hop %d (%s): %w", i, address, err)
+			}
+			clients = append(clients, client)
+			continue
+		}
+
+		previous := clients[len(clients)-1]
+		conn, err := previous.Dial("tcp", address)
+		if err != nil {
+			closeClients(clients)
+			return nil, nil, fmt.Errorf("cannot dial hop %d (%s) through previous hop: %w", i, address, err)
+		}
+
+		ncc, chans, reqs, err := ssh.NewClientConn(conn, address, config)
+		if err != nil {
+			conn.Close()
+			closeClients(clients)
+			return nil, nil, fmt.Errorf("cannot handshake hop %d (%s): %w", i, address, err)
+		}
+		clients = append(clients, ssh.NewClient(ncc, chans, reqs))
+	}
+
+	final := clients[len(clients)-1]
+	intermediate := clients[:len(clients)-1]
+
+	return final, intermediate, nil
+}
+
+// closeClients closes clients in reverse order, as used to unwind a partially-dialed
+// jump chain.
+func closeClients(clients []*ssh.Client) {
+	for i := len(clients) - 1; i >= 0; i-- {
+		clients[i].Close()
+	}
+}
+
+// authMethods builds an ordered list of ssh.AuthMethod from a Connection, preferring
+// certificate- and key-based authentication over the ssh-agent, keyboard-interactive
+// and password fallbacks.
+func authMethods(c *Connection) ([]ssh.AuthMethod, error) {
+	methods := []ssh.AuthMethod{}
+
+	if c.PrivateKey != "" || c.PrivateKeyPath != "" {
+		signer, err := parseSigner(c)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.Certificate != "" {
+			certSigner, err := certSigner(c.Certificate, signer)
+			if err != nil {
+				return nil, err
+			}
+			signer = certSigner
+		}
+
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+
+	if c.UseAgent {
+		socket := os.Getenv("SSH_AUTH_SOCK")
+		if socket == "" {
+			return nil, fmt.Errorf("UseAgent is set but SSH_AUTH_SOCK is not set")
+		}
+
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, fmt.Errorf("cannot dial ssh-agent: %w", err)
+		}
+
+		agentClient := agent.NewClient(conn)
+		methods = append(methods, ssh.PublicKeysCallback(agentClient.Signers))
+	}
+
+	if c.KeyboardInteractive != nil {
+		methods = append(methods, ssh.KeyboardInteractive(ssh.KeyboardInteractiveChallenge(c.KeyboardInteractive)))
+	}
+
+	if c.Password != "" {
+		methods = append(methods, ssh.Password(c.Password))
+	}
+
+	return methods, nil
+}
+
+// parseSigner parses Connection.PrivateKey, falling back to PrivateKeyPath, decrypting
+// it with Connection.Passphrase when the key is encrypted.
+func parseSigner(c *Connection) (ssh.Signer, error) {
+	pemBytes := []byte(c.PrivateKey)
+	if c.PrivateKey == "" {
+		f, err := homedir.Expand(c.PrivateKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot expand private key path: %w", err)
+		}
+
+		pemBytes, err = ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read private key: %w", err)
+		}
+	}
+
+	if c.Passphrase != "" {
+		return ssh.ParsePrivateKeyWithPassphrase(pemBytes, []byte(c.Passphrase))
+	}
+	return ssh.ParsePrivateKey(pemBytes)
+}
+
+// certSigner wraps signer with a CA-signed user certificate, mirroring a Teleport-style
+// login where a short-lived certificate replaces a static password.
+func certSigner(certificate string, signer ssh.Signer) (ssh.Signer, error) {
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse certificate: %w", err)
+	}
+
+	cert, ok := pubKey.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("certificate is not a valid ssh.Certificate")
+	}
+
+	return ssh.NewCertSigner(cert, signer)
+}
+
 //------------------------------------------------------------------------------
 
 func (r *Runner) SetStdoutWriter(stdout io.Writer) {
@@ -199,10 +473,10 @@ func (r *Runner) SetStderrWriter(stderr io.Writer) {
 func (r *Runner) StdoutPipe() (io.Reader, error) {
 	reader, err := r.session.StdoutPipe()
 	if err != nil {
-		r.exitCode = -1
+		r.setExitCode(-1)
 		return nil, &Error{
 			script:   r.script,
-			exitCode: r.exitCode,
+			exitCode: r.ExitCode(),
 			err:      fmt.Errorf("[golang-exec/runner/ssh/StdoutPipe()] cannot create stdout reader: %#w\n", err),
 		}
 	}
@@ -213,10 +487,10 @@ func (r *Runner) StdoutPipe() (io.Reader, error) {
 func (r *Runner) StderrPipe() (io.Reader, error) {
 	reader, err := r.session.StderrPipe()
 	if err != nil {
-		r.exitCode = -1
+		r.setExitCode(-1)
 		return nil, &Error{
 			script:   r.script,
-			exitCode: r.exitCode,
+			exitCode: r.ExitCode(),
 			err:      fmt.Errorf("[golang-exec/runner/ssh/StderrPipe()] cannot create stderr reader: %#w\n", err),
 		}
 	}
@@ -270,75 +544,80 @@ func (r *Runner) Run() error {
 		if err != nil {
 			return err
 		}
+		r.log().Debug("pty-request", "term", "xterm-256color", "width", termWidth, "height", termHeight)
 	}
 
-	fmt.Printf("Running command: %v", r.command)
+	r.log().Info("run-start", "command", r.command)
+	start := time.Now()
 	err := r.session.Run(r.command)
 	if err != nil {
 		var exitErr *ssh.ExitError
 		if errors.As(err, &exitErr) {
-			r.exitCode = exitErr.Waitmsg.ExitStatus()
+			r.setExitCode(exitErr.Waitmsg.ExitStatus())
+			r.log().Error("run-exit", "command", r.command, "exitCode", r.ExitCode(), "duration", time.Since(start))
 			return &Error{
 				script:   r.script,
 				command:  r.command,
-				exitCode: r.exitCode,
+				exitCode: r.ExitCode(),
 				err:      fmt.Errorf("[golang-exec/runner/ssh/Run()] runner failed: %#w\n", err),
 			}
 		} else {
-			r.exitCode = -1
+			r.setExitCode(-1)
+			r.log().Error("run-exit", "command", r.command, "exitCode", r.ExitCode(), "duration", time.Since(start))
 			return &Error{
 				script:   r.script,
 				command:  r.command,
-				exitCode: r.exitCode,
+				exitCode: r.ExitCode(),
 				err:      fmt.Errorf("[golang-exec/runner/ssh/Run()] cannot execute runner: %#w\n", err),
 			}
 		}
 	}
 
-	r.exitCode = 0
+	r.setExitCode(0)
+	r.log().Info("run-exit", "command", r.command, "exitCode", r.ExitCode(), "duration", time.Since(start))
 	return nil
 }
 
 func (r *Runner) Start() error {
 	err := r.session.Start(r.command)
 	if err != nil {
-		r.exitCode = -1
+		r.setExitCode(-1)
 		return &Error{
 			script:   r.script,
 			command:  r.command,
-			exitCode: r.exitCode,
+			exitCode: r.ExitCode(),
 			err:      fmt.Errorf("[golang-exec/runner/ssh/Start()] cannot start runner: %#w\n", err),
 		}
 	}
-	r.running = true
+	r.setRunning(true)
 
 	return nil
 }
 
 func (r *Runner) Wait() error {
 	err := r.session.Wait()
-	r.running = false
+	r.setRunning(false)
 	if err != nil {
 		var exitErr *ssh.ExitError
 		if errors.As(err, &exitErr) {
-			r.exitCode = exitErr.Waitmsg.ExitStatus()
+			r.setExitCode(exitErr.Waitmsg.ExitStatus())
 		} else {
-			r.exitCode = -1
+			r.setExitCode(-1)
 		}
 		return &Error{
 			script:   r.script,
 			command:  r.command,
-			exitCode: r.exitCode,
+			exitCode: r.ExitCode(),
 			err:      fmt.Errorf("[golang-exec/runner/ssh/Wait()] runner failed: %#w\n", err),
 		}
 	}
 
-	r.exitCode = 0
+	r.setExitCode(0)
 	return nil
 }
 
 func (r *Runner) Close() error {
-	if r.running {
+	if r.isRunning() {
 		_ = r.session.Signal(ssh.SIGTERM)
 	}
 
@@ -346,15 +625,45 @@ func (r *Runner) Close() error {
 		_ = r.session.Close()
 	}
 
-	if r.client != nil {
+	if r.pool != nil {
+		r.pool.release(r.poolKey)
+	} else if r.client != nil {
 		r.client.Close()
 	}
 
+	closeClients(r.jumpClients)
+
+	r.log().Info("close")
 	return nil
 }
 
 func (r *Runner) ExitCode() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	return r.exitCode
 }
 
+// setExitCode locks and sets r.exitCode, guarding against concurrent writes from
+// Run/Wait and the RunContext/WaitContext escalation path.
+func (r *Runner) setExitCode(exitCode int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exitCode = exitCode
+}
+
+// setRunning locks and sets r.running, guarding against concurrent writes from
+// Start/Wait and the RunContext/WaitContext escalation path.
+func (r *Runner) setRunning(running bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.running = running
+}
+
+// isRunning locks and reads r.running.
+func (r *Runner) isRunning() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.running
+}
+
 //------------------------------------------------------------------------------
@@ -0,0 +1,128 @@
+//
+// Copyright (c) 2019 Stefaan Coussement
+// MIT License
+//
+// more info: https://github.com/stefaanc/golang-exec
+//
+package ssh
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/sftp"
+)
+
+//------------------------------------------------------------------------------
+
+// Sftp opens an SFTP client on a new session over the Runner's dialed *ssh.Client.
+// The returned client is independent of the Runner's own session and must be closed
+// by the caller.
+func (r *Runner) Sftp() (*sftp.Client, error) {
+	client, err := sftp.NewClient(r.client)
+	if err != nil {
+		return nil, &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/Sftp()] cannot open sftp client: %#w\n", err),
+		}
+	}
+
+	return client, nil
+}
+
+// Upload copies the local file at localPath to remotePath on the remote host.
+func (r *Runner) Upload(localPath, remotePath string) error {
+	local, err := os.Open(localPath)
+	if err != nil {
+		return &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/Upload()] cannot open local file: %#w\n", err),
+		}
+	}
+	defer local.Close()
+
+	info, err := local.Stat()
+	if err != nil {
+		return &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/Upload()] cannot stat local file: %#w\n", err),
+		}
+	}
+
+	return r.UploadReader(local, remotePath, info.Mode())
+}
+
+// UploadReader copies the contents of local to remotePath on the remote host, creating
+// it with the given mode.
+func (r *Runner) UploadReader(local io.Reader, remotePath string, mode os.FileMode) error {
+	client, err := r.Sftp()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	remote, err := client.Create(remotePath)
+	if err != nil {
+		return &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/UploadReader()] cannot create remote file: %#w\n", err),
+		}
+	}
+	defer remote.Close()
+
+	if _, err := io.Copy(remote, local); err != nil {
+		return &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/UploadReader()] cannot copy to remote file: %#w\n", err),
+		}
+	}
+
+	return remote.Chmod(mode)
+}
+
+// Download copies the remote file at remotePath to localPath.
+func (r *Runner) Download(remotePath, localPath string) error {
+	client, err := r.Sftp()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	remote, err := client.Open(remotePath)
+	if err != nil {
+		return &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/Download()] cannot open remote file: %#w\n", err),
+		}
+	}
+	defer remote.Close()
+
+	local, err := os.Create(localPath)
+	if err != nil {
+		return &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/Download()] cannot create local file: %#w\n", err),
+		}
+	}
+	defer local.Close()
+
+	if _, err := io.Copy(local, remote); err != nil {
+		return &Error{
+			script:   r.script,
+			exitCode: -1,
+			err:      fmt.Errorf("[golang-exec/runner/ssh/Download()] cannot copy to local file: %#w\n", err),
+		}
+	}
+
+	return nil
+}
+
+//------------------------------------------------------------------------------